@@ -0,0 +1,99 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceNewRelicGroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNewRelicGroupRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "The name of the group.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"authentication_domain_id": {
+				Type:         schema.TypeString,
+				Description:  "The ID of the authentication domain the group belongs to.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"user_ids": {
+				Type:        schema.TypeList,
+				Description: "IDs of the users currently in the group.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	name := d.Get("name").(string)
+	authenticationDomainId := d.Get("authentication_domain_id").(string)
+
+	log.Printf("[INFO] fetching groups in authentication domain %s to find a group named %s\n", authenticationDomainId, name)
+	getGroupsResponse, err := client.UserManagement.UserManagementGetGroupsWithUsersWithContext(
+		ctx,
+		[]string{authenticationDomainId},
+		[]string{},
+		"",
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if getGroupsResponse == nil {
+		return diag.Errorf("error: failed to fetch groups in authentication domain %s", authenticationDomainId)
+	}
+
+	var matchedGroupID string
+	var matchedUserIDs []string
+	matchCount := 0
+
+	for _, a := range getGroupsResponse.AuthenticationDomains {
+		if a.ID != authenticationDomainId {
+			continue
+		}
+
+		for _, g := range a.Groups.Groups {
+			if g.DisplayName != name {
+				continue
+			}
+
+			matchCount++
+			matchedGroupID = g.ID
+			matchedUserIDs = nil
+			for _, u := range g.Users.Users {
+				matchedUserIDs = append(matchedUserIDs, u.ID)
+			}
+		}
+	}
+
+	if matchCount == 0 {
+		return diag.Errorf("group '%s' not found in authentication domain '%s'", name, authenticationDomainId)
+	}
+
+	if matchCount > 1 {
+		return diag.FromErr(fmt.Errorf("more than one group named '%s' found in authentication domain '%s'", name, authenticationDomainId))
+	}
+
+	d.SetId(matchedGroupID)
+
+	if err := d.Set("user_ids", matchedUserIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/newrelic/newrelic-client-go/v2/newrelic"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/authorizationmanagement"
 	"github.com/newrelic/newrelic-client-go/v2/pkg/usermanagement"
 )
 
@@ -19,6 +20,7 @@ func resourceNewRelicGroupManagement() *schema.Resource {
 		ReadContext:   resourceNewRelicGroupRead,
 		UpdateContext: resourceNewRelicGroupUpdate,
 		DeleteContext: resourceNewRelicGroupDelete,
+		CustomizeDiff: resourceNewRelicGroupCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -42,13 +44,57 @@ func resourceNewRelicGroupManagement() *schema.Resource {
 				Type:        schema.TypeSet,
 				Description: "IDs of users to be added to the group.",
 				Optional:    true,
-				Default:     nil,
+				Computed:    true,
+				Deprecated:  "use the `newrelic_group_membership` resource instead, which allows multiple configurations to contribute members to the same group without fighting over `users`",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"role": {
+				Type:        schema.TypeSet,
+				Description: "Role and account grants to manage directly on this group.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role_id": {
+							Type:         schema.TypeString,
+							Description:  "The ID of the role to grant.",
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"account_id": {
+							Type:        schema.TypeInt,
+							Description: "The ID of the account to grant the role on. Ignored when `organization_scope` is `true`.",
+							Optional:    true,
+						},
+						"organization_scope": {
+							Type:        schema.TypeBool,
+							Description: "Grant the role at the organization level instead of a single account.",
+							Optional:    true,
+							Default:     false,
+						},
+					},
+				},
+			},
+			"role_management_mode": {
+				Type:         schema.TypeString,
+				Description:  "`exclusive` (default) reconciles `role` against the group's live grants, revoking anything not listed. `additive` only grants what's listed in `role` and never revokes, for use when grants are also managed elsewhere.",
+				Optional:     true,
+				Default:      "exclusive",
+				ValidateFunc: validation.StringInSlice([]string{"exclusive", "additive"}, false),
+			},
 		},
 	}
 }
 
+func resourceNewRelicGroupCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	for _, role := range expandGroupRoles(diff.Get("role")) {
+		if !role.organizationScope && role.accountID == 0 {
+			return fmt.Errorf("`account_id` is required on a `role` block with `organization_scope = false` (role_id: %s)", role.roleID)
+		}
+	}
+
+	return nil
+}
+
 func resourceNewRelicGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConfig := meta.(*ProviderConfig)
 	client := providerConfig.NewClient
@@ -86,7 +132,7 @@ func resourceNewRelicGroupCreate(ctx context.Context, d *schema.ResourceData, me
 	usersList := d.Get("users")
 	if usersList == nil {
 		log.Println("[INFO] no users specified in the configuration to add to the group")
-		return nil
+		return resourceNewRelicGroupCreateRoles(ctx, client, d, createdGroupID)
 	}
 
 	ul := usersList.(*schema.Set).List()
@@ -102,7 +148,7 @@ func resourceNewRelicGroupCreate(ctx context.Context, d *schema.ResourceData, me
 
 	if len(usersListCleaned) == 0 {
 		log.Println("[INFO] no users specified in the configuration to add to the group")
-		return nil
+		return resourceNewRelicGroupCreateRoles(ctx, client, d, createdGroupID)
 	}
 
 	log.Printf("[INFO] sending request to add users %v to the created group %s\n", usersListCleaned, createdGroupID)
@@ -115,6 +161,18 @@ func resourceNewRelicGroupCreate(ctx context.Context, d *schema.ResourceData, me
 	}
 
 	log.Printf("[INFO] successfully added the following users to the group %s: %v\n", createdGroupID, usersListCleaned)
+
+	return resourceNewRelicGroupCreateRoles(ctx, client, d, createdGroupID)
+}
+
+func resourceNewRelicGroupCreateRoles(ctx context.Context, client *newrelic.NewRelic, d *schema.ResourceData, groupID string) diag.Diagnostics {
+	desiredRoles := expandGroupRoles(d.Get("role"))
+	for _, role := range desiredRoles {
+		if err := grantGroupRole(ctx, client, groupID, role); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return nil
 }
 
@@ -169,6 +227,13 @@ func resourceNewRelicGroupRead(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
+	// `users` is Optional+Computed so that `newrelic_group_membership` can
+	// manage membership independently: refresh it from the live group
+	// whenever the group has at least one member, so drift is caught when a
+	// practitioner configures `users` directly, but Terraform won't diff the
+	// refreshed value against config when `users` is left unset (the
+	// `newrelic_group_membership` case). A group with no live members leaves
+	// the prior `users` value in state.
 	if len(userListFetched) != 0 {
 		err = d.Set("users", userListFetched)
 		if err != nil {
@@ -180,6 +245,23 @@ func resourceNewRelicGroupRead(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(retryErr)
 	}
 
+	roleGrants, err := getGroupRoleGrants(ctx, client, groupID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("role_management_mode").(string) != "exclusive" {
+		// additive mode only owns the roles listed in config, so grants
+		// created outside Terraform are filtered out here rather than
+		// surfacing as permanent plan diffs - mirroring the non-exclusive
+		// Read on newrelic_group_membership.
+		roleGrants = intersectGroupRoles(roleGrants, expandGroupRoles(d.Get("role")))
+	}
+
+	if err := d.Set("role", flattenGroupRoles(roleGrants)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
@@ -222,7 +304,7 @@ func resourceNewRelicGroupUpdate(ctx context.Context, d *schema.ResourceData, me
 
 	if oldUsersList == nil && newUsersList == nil {
 		log.Println("[INFO] no users specified in the configuration (both previously, and currently) to update the group with")
-		return nil
+		return resourceNewRelicGroupUpdateRoles(ctx, client, d, groupID)
 
 	} else {
 		ol := oldUsersList.(*schema.Set).List()
@@ -244,7 +326,7 @@ func resourceNewRelicGroupUpdate(ctx context.Context, d *schema.ResourceData, me
 
 		if len(oldUsersListCleaned) == 0 && len(newUsersListCleaned) == 0 {
 			log.Println("[INFO] no users specified in the configuration to create the group")
-			return nil
+			return resourceNewRelicGroupUpdateRoles(ctx, client, d, groupID)
 		} else {
 			if len(oldUsersListCleaned) == 0 && len(newUsersListCleaned) != 0 {
 				log.Println("[INFO] new users have been added to the group in the update process. ADDING USERS TO THE GROUP")
@@ -302,7 +384,7 @@ func resourceNewRelicGroupUpdate(ctx context.Context, d *schema.ResourceData, me
 				log.Printf("[INFO] successfully removed the following users from the group %s: %v\n", groupID, deletedUsers)
 			}
 		}
-		return nil
+		return resourceNewRelicGroupUpdateRoles(ctx, client, d, groupID)
 	}
 }
 
@@ -363,4 +445,221 @@ func removeUsersFromGroup(ctx context.Context, client *newrelic.NewRelic, groupI
 	}
 
 	return removeUsersFromGroupResponse, nil
-}
\ No newline at end of file
+}
+
+// groupRoleGrant is a single `role` block: a role granted either on one
+// account, or organization-wide.
+type groupRoleGrant struct {
+	roleID            string
+	accountID         int
+	organizationScope bool
+}
+
+func expandGroupRoles(v interface{}) []groupRoleGrant {
+	set, ok := v.(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	var roles []groupRoleGrant
+	for _, item := range set.List() {
+		m := item.(map[string]interface{})
+		organizationScope := m["organization_scope"].(bool)
+
+		accountID := m["account_id"].(int)
+		if organizationScope {
+			// account_id is meaningless for an organization-wide grant, and
+			// `getGroupRoleGrants` always reads it back as 0 - zero it here
+			// too so the two sides compare equal and don't churn.
+			accountID = 0
+		}
+
+		roles = append(roles, groupRoleGrant{
+			roleID:            m["role_id"].(string),
+			accountID:         accountID,
+			organizationScope: organizationScope,
+		})
+	}
+
+	return roles
+}
+
+func flattenGroupRoles(roles []groupRoleGrant) []map[string]interface{} {
+	flattened := make([]map[string]interface{}, 0, len(roles))
+	for _, role := range roles {
+		flattened = append(flattened, map[string]interface{}{
+			"role_id":            role.roleID,
+			"account_id":         role.accountID,
+			"organization_scope": role.organizationScope,
+		})
+	}
+
+	return flattened
+}
+
+// resourceNewRelicGroupUpdateRoles reconciles the `role` block against the
+// group's grants. In `exclusive` mode (the default) it diffs against both
+// the prior configuration and the group's live grants, revoking anything not
+// listed. In `additive` mode it only grants what's listed and never revokes,
+// for practitioners who manage grants elsewhere.
+func resourceNewRelicGroupUpdateRoles(ctx context.Context, client *newrelic.NewRelic, d *schema.ResourceData, groupID string) diag.Diagnostics {
+	oldRoles, newRoles := d.GetChange("role")
+	oldRolesCleaned := expandGroupRoles(oldRoles)
+	newRolesCleaned := expandGroupRoles(newRoles)
+
+	oldRolesMap := make(map[groupRoleGrant]bool, len(oldRolesCleaned))
+	for _, role := range oldRolesCleaned {
+		oldRolesMap[role] = true
+	}
+	newRolesMap := make(map[groupRoleGrant]bool, len(newRolesCleaned))
+	for _, role := range newRolesCleaned {
+		newRolesMap[role] = true
+	}
+
+	var addedRoles []groupRoleGrant
+	for _, role := range newRolesCleaned {
+		if !oldRolesMap[role] {
+			addedRoles = append(addedRoles, role)
+		}
+	}
+
+	var removedRoles []groupRoleGrant
+	for _, role := range oldRolesCleaned {
+		if !newRolesMap[role] {
+			removedRoles = append(removedRoles, role)
+		}
+	}
+
+	roleManagementMode := d.Get("role_management_mode").(string)
+	if roleManagementMode == "exclusive" {
+		liveGrants, err := getGroupRoleGrants(ctx, client, groupID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, grant := range liveGrants {
+			if !newRolesMap[grant] && !containsRole(removedRoles, grant) {
+				removedRoles = append(removedRoles, grant)
+			}
+		}
+	}
+
+	for _, role := range addedRoles {
+		if err := grantGroupRole(ctx, client, groupID, role); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if roleManagementMode == "exclusive" {
+		for _, role := range removedRoles {
+			if err := revokeGroupRole(ctx, client, groupID, role); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func containsRole(roles []groupRoleGrant, role groupRoleGrant) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// intersectGroupRoles filters liveGrants down to the ones also present in
+// trackedRoles, so a resource that doesn't own the full grant set (e.g.
+// role_management_mode = "additive") doesn't report drift for grants it
+// doesn't manage.
+func intersectGroupRoles(liveGrants []groupRoleGrant, trackedRoles []groupRoleGrant) []groupRoleGrant {
+	var tracked []groupRoleGrant
+	for _, grant := range liveGrants {
+		if containsRole(trackedRoles, grant) {
+			tracked = append(tracked, grant)
+		}
+	}
+	return tracked
+}
+
+// roleGrantInputs builds the account-scoped and organization-scoped grant
+// slices shared by both the grant and revoke mutations.
+func roleGrantInputs(role groupRoleGrant) ([]authorizationmanagement.AuthorizationManagementAccountAccessGrantInput, []authorizationmanagement.AuthorizationManagementOrganizationAccessGrantInput) {
+	if role.organizationScope {
+		return nil, []authorizationmanagement.AuthorizationManagementOrganizationAccessGrantInput{{RoleId: role.roleID}}
+	}
+	return []authorizationmanagement.AuthorizationManagementAccountAccessGrantInput{{AccountId: role.accountID, RoleId: role.roleID}}, nil
+}
+
+func grantGroupRole(ctx context.Context, client *newrelic.NewRelic, groupID string, role groupRoleGrant) error {
+	log.Printf("[INFO] sending request to grant role %s to group %s\n", role.roleID, groupID)
+
+	accountGrants, organizationGrants := roleGrantInputs(role)
+	grantAccessResponse, err := client.AuthorizationManagement.AuthorizationManagementGrantAccessWithContext(ctx, authorizationmanagement.AuthorizationManagementGrantAccessInput{
+		GroupId:                  groupID,
+		AccountAccessGrants:      accountGrants,
+		OrganizationAccessGrants: organizationGrants,
+	})
+	if err != nil {
+		return err
+	}
+	if grantAccessResponse == nil {
+		return fmt.Errorf("error: failed to grant role %s to group %s", role.roleID, groupID)
+	}
+
+	return nil
+}
+
+func revokeGroupRole(ctx context.Context, client *newrelic.NewRelic, groupID string, role groupRoleGrant) error {
+	log.Printf("[INFO] sending request to revoke role %s from group %s\n", role.roleID, groupID)
+
+	accountGrants, organizationGrants := roleGrantInputs(role)
+	revokeAccessResponse, err := client.AuthorizationManagement.AuthorizationManagementRevokeAccessWithContext(ctx, authorizationmanagement.AuthorizationManagementRevokeAccessInput{
+		GroupId:                  groupID,
+		AccountAccessGrants:      accountGrants,
+		OrganizationAccessGrants: organizationGrants,
+	})
+	if err != nil {
+		return err
+	}
+	if revokeAccessResponse == nil {
+		return fmt.Errorf("error: failed to revoke role %s from group %s", role.roleID, groupID)
+	}
+
+	return nil
+}
+
+// getGroupRoleGrants returns the role grants currently held by a group,
+// reading both account-scoped and organization-scoped grants.
+func getGroupRoleGrants(ctx context.Context, client *newrelic.NewRelic, groupID string) ([]groupRoleGrant, error) {
+	grantsResponse, err := client.AuthorizationManagement.AuthorizationManagementAccountAccessGrantsWithContext(ctx, []string{groupID})
+	if err != nil {
+		return nil, err
+	}
+	if grantsResponse == nil {
+		return nil, fmt.Errorf("error fetching role grants for group %s", groupID)
+	}
+
+	var grants []groupRoleGrant
+	for _, g := range grantsResponse.Groups {
+		if g.ID != groupID {
+			continue
+		}
+		for _, a := range g.AccountAccessGrants {
+			grants = append(grants, groupRoleGrant{
+				roleID:    a.Role.ID,
+				accountID: a.AccountID,
+			})
+		}
+		for _, o := range g.OrganizationAccessGrants {
+			grants = append(grants, groupRoleGrant{
+				roleID:            o.Role.ID,
+				organizationScope: true,
+			})
+		}
+	}
+
+	return grants, nil
+}
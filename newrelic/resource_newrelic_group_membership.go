@@ -0,0 +1,277 @@
+package newrelic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/v2/newrelic"
+)
+
+func resourceNewRelicGroupMembership() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNewRelicGroupMembershipCreate,
+		ReadContext:   resourceNewRelicGroupMembershipRead,
+		UpdateContext: resourceNewRelicGroupMembershipUpdate,
+		DeleteContext: resourceNewRelicGroupMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNewRelicGroupMembershipImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:         schema.TypeString,
+				Description:  "The ID of the group the users belong to.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"user_ids": {
+				Type:        schema.TypeSet,
+				Description: "IDs of the users this resource manages the membership of.",
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"exclusive": {
+				Type:        schema.TypeBool,
+				Description: "When `true`, `user_ids` is treated as the complete membership of the group: any user present on the group but missing from `user_ids` is removed. When `false`, this resource only manages the specific users listed in `user_ids`, leaving any other group members (added by other configurations) untouched.",
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+func resourceNewRelicGroupMembershipCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	groupID := d.Get("group_id").(string)
+	if groupID == "" {
+		return diag.FromErr(fmt.Errorf("`group_id` cannot be an empty string"))
+	}
+
+	userIDs := expandGroupMembershipUserIDs(d.Get("user_ids"))
+	if len(userIDs) == 0 {
+		return diag.FromErr(fmt.Errorf("`user_ids` cannot be empty"))
+	}
+
+	log.Printf("[INFO] sending request to add users %v to the group %s\n", userIDs, groupID)
+	_, err := addUsersToGroup(ctx, client, groupID, userIDs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(groupMembershipID(groupID, userIDs))
+	log.Printf("[INFO] successfully added the following users to the group %s: %v\n", groupID, userIDs)
+
+	return resourceNewRelicGroupMembershipRead(ctx, d, meta)
+}
+
+func resourceNewRelicGroupMembershipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	groupID := d.Get("group_id").(string)
+	exclusive := d.Get("exclusive").(bool)
+	trackedIDs := expandGroupMembershipUserIDs(d.Get("user_ids"))
+
+	membersOnServer, err := getGroupMembers(ctx, client, groupID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if exclusive {
+		if err := d.Set("user_ids", membersOnServer); err != nil {
+			return diag.FromErr(err)
+		}
+		return nil
+	}
+
+	// non-exclusive mode: this resource only owns the IDs it was given, so the
+	// server's list is filtered down to the intersection with what's tracked.
+	membersOnServerSet := make(map[string]bool, len(membersOnServer))
+	for _, id := range membersOnServer {
+		membersOnServerSet[id] = true
+	}
+
+	var stillPresent []string
+	for _, id := range trackedIDs {
+		if membersOnServerSet[id] {
+			stillPresent = append(stillPresent, id)
+		}
+	}
+
+	if err := d.Set("user_ids", stillPresent); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceNewRelicGroupMembershipUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	groupID := d.Get("group_id").(string)
+
+	oldUserIDs, newUserIDs := d.GetChange("user_ids")
+	oldUserIDsCleaned := expandGroupMembershipUserIDs(oldUserIDs)
+	newUserIDsCleaned := expandGroupMembershipUserIDs(newUserIDs)
+
+	oldUserIDsMap := make(map[string]bool, len(oldUserIDsCleaned))
+	for _, id := range oldUserIDsCleaned {
+		oldUserIDsMap[id] = true
+	}
+	newUserIDsMap := make(map[string]bool, len(newUserIDsCleaned))
+	for _, id := range newUserIDsCleaned {
+		newUserIDsMap[id] = true
+	}
+
+	var addedUsers []string
+	for _, id := range newUserIDsCleaned {
+		if !oldUserIDsMap[id] {
+			addedUsers = append(addedUsers, id)
+		}
+	}
+
+	var removedUsers []string
+	for _, id := range oldUserIDsCleaned {
+		if !newUserIDsMap[id] {
+			removedUsers = append(removedUsers, id)
+		}
+	}
+
+	if len(addedUsers) > 0 {
+		if _, err := addUsersToGroup(ctx, client, groupID, addedUsers); err != nil {
+			return diag.FromErr(err)
+		}
+		log.Printf("[INFO] successfully added the following users to the group %s: %v\n", groupID, addedUsers)
+	}
+
+	if len(removedUsers) > 0 {
+		if _, err := removeUsersFromGroup(ctx, client, groupID, removedUsers); err != nil {
+			return diag.FromErr(err)
+		}
+		log.Printf("[INFO] successfully removed the following users from the group %s: %v\n", groupID, removedUsers)
+	}
+
+	// The content-addressed ID is only meaningful in exclusive mode, where
+	// `user_ids` is the complete membership; recomputing it there lets
+	// `terraform import` target a specific membership snapshot. In
+	// non-exclusive mode the ID stays whatever it was set to on Create, since
+	// this resource only ever owns a delta of the group's membership.
+	if d.Get("exclusive").(bool) {
+		d.SetId(groupMembershipID(groupID, newUserIDsCleaned))
+	}
+
+	return resourceNewRelicGroupMembershipRead(ctx, d, meta)
+}
+
+// resourceNewRelicGroupMembershipImport splits the composite
+// `<group_id>:<hash>` ID set by Create/Update back into `group_id`, since
+// `ImportStatePassthroughContext` only sets the opaque resource ID. Import
+// only supports exclusive mode, where the full membership is read back from
+// the server; `exclusive` is seeded to `true` and `user_ids` is left for
+// Read to populate.
+func resourceNewRelicGroupMembershipImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	groupID, _, ok := strings.Cut(d.Id(), ":")
+	if !ok || groupID == "" {
+		return nil, fmt.Errorf("invalid import ID %q: expected format <group_id>:<hash>", d.Id())
+	}
+
+	if err := d.Set("group_id", groupID); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("exclusive", true); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceNewRelicGroupMembershipDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	groupID := d.Get("group_id").(string)
+	trackedIDs := expandGroupMembershipUserIDs(d.Get("user_ids"))
+
+	if len(trackedIDs) == 0 {
+		return nil
+	}
+
+	if _, err := removeUsersFromGroup(ctx, client, groupID, trackedIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] successfully removed the following users from the group %s: %v\n", groupID, trackedIDs)
+
+	return nil
+}
+
+// getGroupMembers returns the IDs of all users currently in the given group.
+func getGroupMembers(ctx context.Context, client *newrelic.NewRelic, groupID string) ([]string, error) {
+	getUsersInGroupsResponse, err := client.UserManagement.UserManagementGetGroupsWithUsersWithContext(
+		ctx,
+		[]string{},
+		[]string{groupID},
+		"",
+	)
+	if err != nil {
+		return nil, err
+	}
+	if getUsersInGroupsResponse == nil {
+		return nil, fmt.Errorf("error fetching group: %s", groupID)
+	}
+
+	var members []string
+	for _, a := range getUsersInGroupsResponse.AuthenticationDomains {
+		for _, g := range a.Groups.Groups {
+			if g.ID == groupID {
+				for _, u := range g.Users.Users {
+					members = append(members, u.ID)
+				}
+			}
+		}
+	}
+
+	return members, nil
+}
+
+func expandGroupMembershipUserIDs(v interface{}) []string {
+	if v == nil {
+		return nil
+	}
+
+	set, ok := v.(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	var userIDs []string
+	for _, u := range set.List() {
+		if str, ok := u.(string); ok {
+			userIDs = append(userIDs, str)
+		}
+	}
+
+	return userIDs
+}
+
+// groupMembershipID builds a composite `<group_id>:<hash-of-user-ids>` ID so
+// that `terraform import` can round-trip a membership resource.
+func groupMembershipID(groupID string, userIDs []string) string {
+	sorted := append([]string{}, userIDs...)
+	sort.Strings(sorted)
+
+	hash := sha256.Sum256([]byte(strings.Join(sorted, ",")))
+
+	return fmt.Sprintf("%s:%s", groupID, hex.EncodeToString(hash[:])[:12])
+}